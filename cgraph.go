@@ -0,0 +1,169 @@
+package graph
+
+import (
+	"container/heap"
+	"math"
+)
+
+// Heuristic estimates the remaining distance from a vertex to another. It is
+// used by (*CGraph).AStarPath to steer the search toward dest. AStarPath
+// closes a vertex the first time it is popped, which is only optimal for a
+// consistent heuristic: one that, in addition to never overestimating the
+// real distance (admissible), also never decreases by more than the weight
+// of the edge it crosses, i.e. h(a, dest) <= weight(a, b) + h(b, dest) for
+// every edge a->b. The three built-in heuristics are consistent; a merely
+// admissible custom Heuristic may make AStarPath return a suboptimal path.
+type Heuristic func(from, to Vertex) int
+
+type coordinate struct {
+	x, y float64
+}
+
+// CGraph is a Graph whose vertices carry (x, y) coordinates. The coordinates
+// let CGraph run the A* search algorithm, which Graph cannot do on its own.
+// Create a CGraph with NewCGraph, and add vertices with (*CGraph).NewVertex
+// instead of the embedded Graph's NewVertex.
+type CGraph struct {
+	*Graph
+	coords map[VertexID]coordinate
+}
+
+// NewCGraph returns an initialized CGraph.
+func NewCGraph(t Type) *CGraph {
+	return &CGraph{
+		Graph:  New(t),
+		coords: make(map[VertexID]coordinate),
+	}
+}
+
+// NewVertex returns a new vertex located at (x, y).
+func (g *CGraph) NewVertex(x, y float64) Vertex {
+	v := g.Graph.NewVertex()
+	g.coords[v.ID()] = coordinate{x: x, y: y}
+	return v
+}
+
+// EuclideanHeuristic estimates the distance between from and to as their
+// straight-line distance, rounded to the nearest int. It is admissible for
+// any graph whose edge weights are at least the Euclidean distance they
+// span, and is the usual default heuristic for AStarPath.
+func (g *CGraph) EuclideanHeuristic(from, to Vertex) int {
+	cf, ct := g.coords[from.ID()], g.coords[to.ID()]
+	dx, dy := cf.x-ct.x, cf.y-ct.y
+	return int(math.Round(math.Sqrt(dx*dx + dy*dy)))
+}
+
+// ManhattanHeuristic estimates the distance between from and to as the sum
+// of the absolute differences of their coordinates. It suits graphs that
+// only allow axis-aligned movement.
+func (g *CGraph) ManhattanHeuristic(from, to Vertex) int {
+	cf, ct := g.coords[from.ID()], g.coords[to.ID()]
+	return int(math.Round(math.Abs(cf.x-ct.x) + math.Abs(cf.y-ct.y)))
+}
+
+// ChebyshevHeuristic estimates the distance between from and to as the
+// greatest absolute difference of their coordinates. It suits graphs that
+// allow diagonal movement at the same cost as axis-aligned movement.
+func (g *CGraph) ChebyshevHeuristic(from, to Vertex) int {
+	cf, ct := g.coords[from.ID()], g.coords[to.ID()]
+	return int(math.Round(math.Max(math.Abs(cf.x-ct.x), math.Abs(cf.y-ct.y))))
+}
+
+// astarStep remembers how a vertex was reached during AStarPath, so the
+// winning path can be rebuilt by walking backward from dest to src.
+type astarStep struct {
+	from   vertexible
+	weight int
+}
+
+// astarEntry is an open-set entry ordered by f = g + h.
+type astarEntry struct {
+	to vertexible
+	g  int
+	f  int
+}
+
+type astarHeap []astarEntry
+
+func (h astarHeap) Len() int            { return len(h) }
+func (h astarHeap) Less(i, j int) bool  { return h[i].f < h[j].f }
+func (h astarHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *astarHeap) Push(x interface{}) { *h = append(*h, x.(astarEntry)) }
+func (h *astarHeap) Pop() interface{} {
+	old := *h
+	size := len(old)
+	popped := old[size-1]
+	*h = old[:size-1]
+	return popped
+}
+
+// AStarPath returns the shortest path from src to dest, using h to guide the
+// search. If h is nil, AStarPath falls back to Dijkstra's algorithm via the
+// embedded Graph's ShortestPath. AStarPath panics if it encounters a
+// reachable edge with negative weight, since A* requires non-negative edge
+// weights to guarantee an optimal path, same as Dijkstra. h must be
+// consistent (see Heuristic) for the returned path to be guaranteed
+// shortest.
+func (g *CGraph) AStarPath(src, dest Vertex, h Heuristic) Path {
+	if h == nil {
+		return g.Graph.ShortestPath(src, dest)
+	}
+
+	gScore := map[vertexible]int{src.vertex: 0}
+	cameFrom := make(map[vertexible]astarStep)
+	closed := make(map[vertexible]bool)
+
+	open := &astarHeap{{to: src.vertex, g: 0, f: h(src, dest)}}
+	heap.Init(open)
+
+	for open.Len() > 0 {
+		current := heap.Pop(open).(astarEntry)
+		if closed[current.to] {
+			continue
+		}
+		closed[current.to] = true
+
+		if current.to == dest.vertex {
+			return buildAStarPath(cameFrom, src.vertex, dest.vertex)
+		}
+
+		for _, e := range current.to.edges() {
+			if e.weight < 0 {
+				panic("graph: AStarPath encountered a negative edge weight")
+			}
+			if closed[e.to] {
+				continue
+			}
+			newG := current.g + e.weight
+			if oldG, ok := gScore[e.to]; !ok || newG < oldG {
+				gScore[e.to] = newG
+				cameFrom[e.to] = astarStep{from: current.to, weight: e.weight}
+				heap.Push(open, astarEntry{
+					to: e.to,
+					g:  newG,
+					f:  newG + h(e.to.accessor(), dest),
+				})
+			}
+		}
+	}
+
+	return Path{}
+}
+
+func buildAStarPath(cameFrom map[vertexible]astarStep, src, dest vertexible) Path {
+	var chain []edge
+	for cur := dest; cur != src; {
+		step, ok := cameFrom[cur]
+		if !ok {
+			return Path{}
+		}
+		chain = append(chain, edge{to: cur, weight: step.weight})
+		cur = step.from
+	}
+
+	var p Path
+	for i := len(chain) - 1; i >= 0; i-- {
+		p.addEdge(chain[i])
+	}
+	return p
+}
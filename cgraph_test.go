@@ -0,0 +1,47 @@
+package graph
+
+import "testing"
+
+func TestAStarPath(t *testing.T) {
+	graph := NewCGraph(Directional)
+	vertices := []Vertex{
+		graph.NewVertex(0, 0),
+		graph.NewVertex(1, 0),
+		graph.NewVertex(2, 0),
+		graph.NewVertex(2, 1),
+		graph.NewVertex(0, 1),
+	}
+	graph.AddEdge(vertices[0], vertices[1], 1)
+	graph.AddEdge(vertices[1], vertices[2], 1)
+	graph.AddEdge(vertices[2], vertices[3], 1)
+	graph.AddEdge(vertices[0], vertices[4], 1)
+	graph.AddEdge(vertices[4], vertices[3], 1)
+
+	path := graph.AStarPath(vertices[0], vertices[3], graph.EuclideanHeuristic)
+	t.Logf("weight(%d): %v", path.Distance(), path)
+
+	dest, ok := path.Destination()
+	if !ok || dest.ID() != vertices[3].ID() {
+		t.Fatalf("expected destination %s, got %v (ok=%v)", vertices[3].ID(), dest, ok)
+	}
+	if path.Distance() != 2 {
+		t.Fatalf("expected distance 2, got %d", path.Distance())
+	}
+}
+
+func TestAStarPathFallsBackToDijkstra(t *testing.T) {
+	graph := NewCGraph(Directional)
+	vertices := []Vertex{
+		graph.NewVertex(0, 0),
+		graph.NewVertex(1, 0),
+		graph.NewVertex(2, 0),
+	}
+	graph.AddEdge(vertices[0], vertices[1], 1)
+	graph.AddEdge(vertices[1], vertices[2], 1)
+
+	path := graph.AStarPath(vertices[0], vertices[2], nil)
+	t.Logf("weight(%d): %v", path.Distance(), path)
+	if path.Distance() != 2 {
+		t.Fatalf("expected distance 2, got %d", path.Distance())
+	}
+}
@@ -0,0 +1,60 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+)
+
+// WriteDOT writes g to w in Graphviz DOT format: "digraph" with "->" edges
+// for Directional graphs, "graph" with "--" edges for Bidirectional ones.
+// Each edge is labeled with its weight. A vertex is labeled with its Value
+// when Value implements fmt.Stringer.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	keyword, arrow := "digraph", "->"
+	if g.Type == Bidirectional {
+		keyword, arrow = "graph", "--"
+	}
+
+	if _, err := fmt.Fprintf(w, "%s {\n", keyword); err != nil {
+		return err
+	}
+
+	seen := make(map[vertexPair]bool)
+	for _, v := range g.vertices {
+		if _, err := fmt.Fprintf(w, "\t%s%s;\n", v.id(), vertexLabel(v)); err != nil {
+			return err
+		}
+		for _, e := range v.edges() {
+			if g.Type == Bidirectional {
+				if seen[vertexPair{from: e.to.id(), to: v.id()}] {
+					continue
+				}
+				seen[vertexPair{from: v.id(), to: e.to.id()}] = true
+			}
+			if _, err := fmt.Fprintf(w, "\t%s %s %s [label=%q];\n",
+				v.id(), arrow, e.to.id(), fmt.Sprint(e.weight)); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
+
+// vertexLabel returns a DOT label attribute for v if its Value implements
+// fmt.Stringer, or an empty string otherwise.
+func vertexLabel(v vertexible) string {
+	value := v.accessor().Value
+	if value == nil {
+		return ""
+	}
+	stringer, ok := (*value).(fmt.Stringer)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf(" [label=%q]", stringer.String())
+}
@@ -0,0 +1,41 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteDOT(t *testing.T) {
+	graph := New(Directional)
+	vertices := []Vertex{graph.NewVertex(), graph.NewVertex()}
+	graph.AddEdge(vertices[0], vertices[1], 3)
+
+	var out strings.Builder
+	if err := graph.WriteDOT(&out); err != nil {
+		t.Fatalf("WriteDOT returned error: %v", err)
+	}
+	t.Log(out.String())
+
+	if !strings.HasPrefix(out.String(), "digraph {") {
+		t.Fatalf("expected DOT output to start with \"digraph {\", got %q", out.String())
+	}
+	if !strings.Contains(out.String(), "->") {
+		t.Fatalf("expected a directed edge in DOT output, got %q", out.String())
+	}
+}
+
+func TestWriteDOTBidirectional(t *testing.T) {
+	graph := New(Bidirectional)
+	vertices := []Vertex{graph.NewVertex(), graph.NewVertex()}
+	graph.AddEdge(vertices[0], vertices[1], 1)
+
+	var out strings.Builder
+	if err := graph.WriteDOT(&out); err != nil {
+		t.Fatalf("WriteDOT returned error: %v", err)
+	}
+	t.Log(out.String())
+
+	if strings.Count(out.String(), "--") != 1 {
+		t.Fatalf("expected exactly one undirected edge line, got %q", out.String())
+	}
+}
@@ -0,0 +1,207 @@
+package graph
+
+// fibNode is a node of a fibonacciHeap.
+type fibNode struct {
+	to          vertexible
+	weight      int
+	parent      *fibNode
+	child       *fibNode
+	left, right *fibNode
+	degree      int
+	marked      bool
+}
+
+// fibonacciHeap is a distancePQ implementing a Fibonacci heap. Unlike
+// indexedHeap, decreaseKey runs in amortized O(1) rather than O(log V),
+// which gives Dijkstra its textbook O(E + V log V) running time instead of
+// indexedHeap's O(E log V).
+type fibonacciHeap struct {
+	min   *fibNode
+	count int
+	index map[vertexible]*fibNode
+}
+
+// newFibonacciHeap builds a fibonacciHeap from items and returns it as a
+// distancePQ, ready for pop and decreaseKey. Every insertion is O(1), so
+// building the heap is O(V).
+func newFibonacciHeap(items []edge) distancePQ {
+	h := &fibonacciHeap{index: make(map[vertexible]*fibNode, len(items))}
+	for _, e := range items {
+		h.insert(e.to, e.weight)
+	}
+	return h
+}
+
+func (h *fibonacciHeap) Len() int { return h.count }
+
+func (h *fibonacciHeap) insert(v vertexible, weight int) {
+	n := &fibNode{to: v, weight: weight}
+	n.left, n.right = n, n
+	h.addToRootList(n)
+	h.index[v] = n
+	h.count++
+	if h.min == nil || distanceLess(n.weight, h.min.weight) {
+		h.min = n
+	}
+}
+
+func (h *fibonacciHeap) addToRootList(n *fibNode) {
+	if h.min == nil {
+		n.left, n.right = n, n
+		return
+	}
+	n.left = h.min
+	n.right = h.min.right
+	h.min.right.left = n
+	h.min.right = n
+}
+
+func (h *fibonacciHeap) pop() edge {
+	z := h.min
+	if z == nil {
+		return edge{weight: -1}
+	}
+
+	if z.child != nil {
+		start := z.child
+		c := start
+		for {
+			next := c.right
+			c.parent = nil
+			h.addToRootList(c)
+			c = next
+			if c == start {
+				break
+			}
+		}
+	}
+
+	z.left.right = z.right
+	z.right.left = z.left
+	if z == z.right {
+		h.min = nil
+	} else {
+		h.min = z.right
+		h.consolidate()
+	}
+
+	h.count--
+	delete(h.index, z.to)
+	return edge{to: z.to, weight: z.weight}
+}
+
+// consolidate merges root-list trees of equal degree until every root has
+// a distinct degree, restoring the heap property at the root list.
+func (h *fibonacciHeap) consolidate() {
+	const maxDegree = 64
+	byDegree := make([]*fibNode, maxDegree)
+
+	var roots []*fibNode
+	if h.min != nil {
+		c := h.min
+		for {
+			roots = append(roots, c)
+			c = c.right
+			if c == h.min {
+				break
+			}
+		}
+	}
+
+	for _, w := range roots {
+		x := w
+		d := x.degree
+		for byDegree[d] != nil {
+			y := byDegree[d]
+			if distanceLess(y.weight, x.weight) {
+				x, y = y, x
+			}
+			h.link(y, x)
+			byDegree[d] = nil
+			d++
+		}
+		byDegree[d] = x
+	}
+
+	h.min = nil
+	for _, n := range byDegree {
+		if n == nil {
+			continue
+		}
+		n.left, n.right = n, n
+		h.addToRootList(n)
+		if h.min == nil || distanceLess(n.weight, h.min.weight) {
+			h.min = n
+		}
+	}
+}
+
+// link makes y a child of x, removing y from the root list.
+func (h *fibonacciHeap) link(y, x *fibNode) {
+	y.left.right = y.right
+	y.right.left = y.left
+
+	y.parent = x
+	if x.child == nil {
+		x.child = y
+		y.left, y.right = y, y
+	} else {
+		y.left = x.child
+		y.right = x.child.right
+		x.child.right.left = y
+		x.child.right = y
+	}
+	x.degree++
+	y.marked = false
+}
+
+func (h *fibonacciHeap) decreaseKey(v vertexible, weight int) {
+	n, ok := h.index[v]
+	if !ok || !distanceLess(weight, n.weight) {
+		return
+	}
+	n.weight = weight
+
+	p := n.parent
+	if p != nil && distanceLess(n.weight, p.weight) {
+		h.cut(n, p)
+		h.cascadingCut(p)
+	}
+	if distanceLess(n.weight, h.min.weight) {
+		h.min = n
+	}
+}
+
+// cut detaches n from its parent p and moves it to the root list.
+func (h *fibonacciHeap) cut(n, p *fibNode) {
+	if n.right == n {
+		p.child = nil
+	} else {
+		n.left.right = n.right
+		n.right.left = n.left
+		if p.child == n {
+			p.child = n.right
+		}
+	}
+	p.degree--
+
+	n.parent = nil
+	n.marked = false
+	n.left, n.right = n, n
+	h.addToRootList(n)
+}
+
+// cascadingCut propagates cuts up the tree: a once-marked node that loses a
+// second child is itself cut from its parent.
+func (h *fibonacciHeap) cascadingCut(n *fibNode) {
+	p := n.parent
+	if p == nil {
+		return
+	}
+	if !n.marked {
+		n.marked = true
+		return
+	}
+	h.cut(n, p)
+	h.cascadingCut(p)
+}
@@ -2,7 +2,6 @@
 package graph
 
 import (
-	"container/heap"
 	"fmt"
 	"strings"
 	"sync"
@@ -21,8 +20,14 @@ const (
 // Graph implements an adjacency list. You should create a Graph by calling
 // New(Type) function. As Graph doest have any location or coordinate, Graph
 // cannot use A* algorithm. If you want A* algorithm, use CGraph instead.
+//
+// Graph is safe for concurrent use: mutating methods take a write lock and
+// read-only methods take a read lock. Algorithms that need to read g
+// extensively without blocking concurrent writers should call Snapshot
+// first.
 type Graph struct {
 	Type
+	mu         sync.RWMutex
 	vertices   map[VertexID]vertexible
 	generateID func() VertexID
 }
@@ -37,11 +42,16 @@ type Path struct {
 	edges []edge
 }
 
-// distanceHeap implements min-heap interface for algorithm operations.
+// distanceHeap orders edges by weight, treating negative weights as
+// unreached. It backs indexedHeap, which wraps it with an index for O(log
+// V) decrease-key.
 type distanceHeap []edge
 
 // NewVertex returns a new vertex which is ready to use.
 func (g *Graph) NewVertex() Vertex {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	newVertex := &vertex{VertexID: g.generateID()}
 	newVertex.container = Vertex{
 		vertex: newVertex,
@@ -51,9 +61,26 @@ func (g *Graph) NewVertex() Vertex {
 	return newVertex.container
 }
 
+// Vertex returns the vertex with 'id'. ok is false if no such vertex
+// exists, for example because it was looked up on a Snapshot by an id from
+// a different Graph.
+func (g *Graph) Vertex(id VertexID) (v Vertex, ok bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	found, ok := g.vertices[id]
+	if !ok {
+		return v, false
+	}
+	return found.accessor(), true
+}
+
 // RemoveVertex removes the vertex with 'id'. Then edges that point to
 // the removed vertex are also removed. Returns true if the vertex is removed.
 func (g *Graph) RemoveVertex(id VertexID) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	if _, ok := g.vertices[id]; !ok {
 		return false
 	}
@@ -66,6 +93,9 @@ func (g *Graph) RemoveVertex(id VertexID) bool {
 
 // AddEdge adds a new edge with weight from Vertex to Vertex.
 func (g *Graph) AddEdge(from, to Vertex, weight int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	from.vertex.addEdge(to.vertex, weight)
 	if g.Type == Bidirectional {
 		to.vertex.addEdge(from.vertex, weight)
@@ -75,36 +105,81 @@ func (g *Graph) AddEdge(from, to Vertex, weight int) {
 // RemoveEdges removes all edges from 'from' to 'to'. If Type of g is
 // Directional, the other edges (from 'to' to 'from') are not removed.
 func (g *Graph) RemoveEdges(from, to Vertex) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	from.vertex.removeEdge(to.ID())
 	if g.Type == Bidirectional {
 		to.vertex.removeEdge(from.ID())
 	}
 }
 
-func (g *Graph) dijkstra(src vertexible, handler func(vertexible, Path) bool) {
+// vertexPair identifies a directed edge by the VertexIDs of its endpoints,
+// for use as a map key in dijkstraExclusions.
+type vertexPair struct {
+	from, to VertexID
+}
+
+// dijkstraExclusions hides vertices and edges from dijkstra without
+// mutating the Graph, so algorithms built on top of dijkstra (such as
+// KShortestPaths) can explore alternatives to an already-found path. A nil
+// *dijkstraExclusions excludes nothing.
+type dijkstraExclusions struct {
+	vertices map[VertexID]bool
+	edges    map[vertexPair]bool
+}
+
+func (e *dijkstraExclusions) excludesVertex(id VertexID) bool {
+	return e != nil && e.vertices[id]
+}
+
+func (e *dijkstraExclusions) excludesEdge(from, to VertexID) bool {
+	return e != nil && e.edges[vertexPair{from: from, to: to}]
+}
+
+// dijkstra assumes the caller already holds at least a read lock on g.mu.
+// It runs Dijkstra's algorithm with indexedHeap as its priority queue; use
+// dijkstraWithPQ directly to plug in a different distancePQ, such as
+// fibonacciHeap.
+func (g *Graph) dijkstra(src vertexible, excl *dijkstraExclusions, handler func(vertexible, Path) bool) {
+	g.dijkstraWithPQ(src, excl, handler, newIndexedHeap)
+}
+
+// dijkstraWithPQ is dijkstra parameterized over the distancePQ
+// implementation, so callers (and benchmarks) can compare priority queues
+// without duplicating the traversal.
+func (g *Graph) dijkstraWithPQ(
+	src vertexible,
+	excl *dijkstraExclusions,
+	handler func(vertexible, Path) bool,
+	newPQ func([]edge) distancePQ,
+) {
 	var shortestPaths = make(map[vertexible]Path)
 	for _, v := range g.vertices {
-		if v != src {
+		if v != src && !excl.excludesVertex(v.id()) {
 			shortestPaths[v] = Path{}
 		}
 	}
 
-	var distHeap = distanceHeap(make([]edge, 0, len(g.vertices)))
+	items := make([]edge, 0, len(g.vertices))
 	for _, v := range g.vertices {
+		if v != src && excl.excludesVertex(v.id()) {
+			continue
+		}
 		weight := -1
 		if v == src {
 			weight = 0
 		}
-		distHeap = append(distHeap, edge{
+		items = append(items, edge{
 			to:     v,
 			weight: weight,
 		})
 	}
-	heap.Init(&distHeap)
+	pq := newPQ(items)
 
-	entireSize := len(distHeap)
+	entireSize := pq.Len()
 	for i := 0; i < entireSize; i++ {
-		closestEdge := heap.Pop(&distHeap).(edge)
+		closestEdge := pq.pop()
 		if closestEdge.weight < 0 {
 			break
 		}
@@ -117,24 +192,27 @@ func (g *Graph) dijkstra(src vertexible, handler func(vertexible, Path) bool) {
 			if e.to == src {
 				continue
 			}
+			if excl.excludesVertex(e.to.id()) || excl.excludesEdge(closestEdge.to.id(), e.to.id()) {
+				continue
+			}
 			newW := closestEdge.weight + e.weight
 			oldW := shortestPaths[e.to].Distance()
 			if oldW < 0 || newW < oldW {
-				fixedPath := shortestPaths[closestEdge.to]
+				prefix := shortestPaths[closestEdge.to]
+				fixedPath := Path{edges: append([]edge(nil), prefix.edges...)}
 				fixedPath.addEdge(e)
 				shortestPaths[e.to] = fixedPath
-				distHeap.update(e.to, newW)
+				pq.decreaseKey(e.to, newW)
 			}
 		}
 	}
 }
 
-// ShortestPath returns shortest path p from src to dest. You can check whether
-// the path exists by checking p.Destination() or p.Distance(). As g
-// cannot be applied A* algorithm, ShortestPath uses Dijkstra's one instead.
-func (g *Graph) ShortestPath(src, dest Vertex) (p Path) {
-	g.dijkstra(src.vertex, func(v vertexible, shortest Path) bool {
-		if v == dest.vertex {
+// shortestPath is the lock-free core of ShortestPath, reused by algorithms
+// such as KShortestPaths that already hold g.mu for their whole operation.
+func (g *Graph) shortestPath(src, dest vertexible) (p Path) {
+	g.dijkstra(src, nil, func(v vertexible, shortest Path) bool {
+		if v == dest {
 			p = shortest
 			return false
 		}
@@ -143,11 +221,23 @@ func (g *Graph) ShortestPath(src, dest Vertex) (p Path) {
 	return
 }
 
+// ShortestPath returns shortest path p from src to dest. You can check whether
+// the path exists by checking p.Destination() or p.Distance(). As g
+// cannot be applied A* algorithm, ShortestPath uses Dijkstra's one instead.
+func (g *Graph) ShortestPath(src, dest Vertex) Path {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.shortestPath(src.vertex, dest.vertex)
+}
+
 // ShortestPaths returns shortest paths from source to every vertices which
 // are reachable from source.
 func (g *Graph) ShortestPaths(source Vertex) map[Vertex]Path {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
 	var dists = make(map[Vertex]Path)
-	g.dijkstra(source.vertex, func(v vertexible, p Path) bool {
+	g.dijkstra(source.vertex, nil, func(v vertexible, p Path) bool {
 		dists[v.accessor()] = p
 		return true
 	})
@@ -155,6 +245,9 @@ func (g *Graph) ShortestPaths(source Vertex) map[Vertex]Path {
 }
 
 func (g *Graph) String() string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
 	var result strings.Builder
 	for _, v := range g.vertices {
 		result.WriteString(v.String() + "\n")
@@ -217,11 +310,24 @@ func (p *Path) addEdge(target edge) error {
 	return nil
 }
 
+// addEdgeSigned is a sibling of addEdge that accepts negative weights. It
+// backs the algorithms in this package that are defined over negative
+// weights, such as BellmanFordFrom and AllShortestPaths.
+func (p *Path) addEdgeSigned(target edge) {
+	p.edges = append(p.edges, target)
+}
+
 func (d distanceHeap) Len() int { return len(d) }
 
 func (d distanceHeap) Less(i, j int) bool {
-	wi, wj := d[i].weight, d[j].weight
-	// treat negative numbers as if it is greater than any positive numbers.
+	return distanceLess(d[i].weight, d[j].weight)
+}
+
+// distanceLess orders tentative distances, treating a negative weight as
+// unreached, i.e. greater than any non-negative weight. Both distanceHeap
+// and fibonacciHeap order by this rule, so unreached vertices always sort
+// last regardless of which priority queue dijkstra is using.
+func distanceLess(wi, wj int) bool {
 	if wi < 0 {
 		return false
 	} else if wj < 0 {
@@ -232,42 +338,23 @@ func (d distanceHeap) Less(i, j int) bool {
 
 func (d distanceHeap) Swap(i, j int) { d[i], d[j] = d[j], d[i] }
 
-func (d *distanceHeap) Push(x interface{}) {
-	*d = append(*d, x.(edge))
-}
-
-func (d *distanceHeap) Pop() interface{} {
-	old := *d
-	size := len(old)
-	popped := old[size-1]
-	*d = old[:size-1]
-	return popped
-}
-
-func (d distanceHeap) update(v vertexible, weight int) {
-	for i, e := range d {
-		if v == e.to {
-			d[i].weight = weight
-			heap.Fix(&d, i)
-			return
-		}
+// newIDGenerator returns a function that generates sequential VertexIDs
+// starting after last, safe for concurrent use.
+func newIDGenerator(last VertexID) func() VertexID {
+	var lock sync.Mutex
+	return func() VertexID {
+		lock.Lock()
+		defer lock.Unlock()
+		last++
+		return last
 	}
 }
 
 // New returns initialized Graph.
 func New(t Type) *Graph {
 	return &Graph{
-		Type:     t,
-		vertices: make(map[VertexID]vertexible),
-		generateID: func() func() VertexID {
-			var vertexIDLast VertexID
-			var vertexIDLock sync.Mutex
-			return func() VertexID {
-				vertexIDLock.Lock()
-				defer vertexIDLock.Unlock()
-				vertexIDLast++
-				return vertexIDLast
-			}
-		}(),
+		Type:       t,
+		vertices:   make(map[VertexID]vertexible),
+		generateID: newIDGenerator(0),
 	}
 }
@@ -0,0 +1,95 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// jsonEdge is the JSON representation of a single outgoing edge.
+type jsonEdge struct {
+	To     VertexID `json:"to"`
+	Weight int      `json:"weight"`
+}
+
+// jsonVertex is the JSON representation of a vertex and its outgoing edges.
+type jsonVertex struct {
+	ID    VertexID    `json:"id"`
+	Value interface{} `json:"value,omitempty"`
+	Edges []jsonEdge  `json:"edges,omitempty"`
+}
+
+// jsonGraph is the JSON representation of a Graph.
+type jsonGraph struct {
+	Type     Type         `json:"type"`
+	Vertices []jsonVertex `json:"vertices"`
+}
+
+// MarshalJSON encodes g as JSON, preserving VertexIDs, edges with their
+// weights, and the graph's Type.
+func (g *Graph) MarshalJSON() ([]byte, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	ids := make([]VertexID, 0, len(g.vertices))
+	for id := range g.vertices {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	jg := jsonGraph{
+		Type:     g.Type,
+		Vertices: make([]jsonVertex, 0, len(ids)),
+	}
+	for _, id := range ids {
+		v := g.vertices[id]
+		jv := jsonVertex{ID: id}
+		if value := v.accessor().Value; value != nil {
+			jv.Value = *value
+		}
+		for _, e := range v.edges() {
+			jv.Edges = append(jv.Edges, jsonEdge{To: e.to.id(), Weight: e.weight})
+		}
+		jg.Vertices = append(jg.Vertices, jv)
+	}
+	return json.Marshal(jg)
+}
+
+// UnmarshalJSON decodes a Graph previously encoded with MarshalJSON,
+// reconstructing its vertices (with their original VertexIDs), edges, and
+// Type. It replaces the receiver's state entirely.
+func (g *Graph) UnmarshalJSON(data []byte) error {
+	var jg jsonGraph
+	if err := json.Unmarshal(data, &jg); err != nil {
+		return err
+	}
+
+	vertices := make(map[VertexID]vertexible, len(jg.Vertices))
+	var maxID VertexID
+	for _, jv := range jg.Vertices {
+		newVertex := &vertex{VertexID: jv.ID}
+		newVertex.container = Vertex{vertex: newVertex, Value: new(interface{})}
+		*newVertex.container.Value = jv.Value
+		vertices[jv.ID] = newVertex
+		if jv.ID > maxID {
+			maxID = jv.ID
+		}
+	}
+	for _, jv := range jg.Vertices {
+		from := vertices[jv.ID]
+		for _, je := range jv.Edges {
+			to, ok := vertices[je.To]
+			if !ok {
+				return fmt.Errorf("graph: edge from %s references unknown vertex %s", jv.ID, je.To)
+			}
+			from.addEdge(to, je.Weight)
+		}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.Type = jg.Type
+	g.vertices = vertices
+	g.generateID = newIDGenerator(maxID)
+	return nil
+}
@@ -0,0 +1,48 @@
+package graph
+
+import "testing"
+
+func TestGraphJSONRoundTrip(t *testing.T) {
+	original := New(Directional)
+	vertices := []Vertex{
+		original.NewVertex(),
+		original.NewVertex(),
+		original.NewVertex(),
+	}
+	original.AddEdge(vertices[0], vertices[1], 1)
+	original.AddEdge(vertices[1], vertices[2], 2)
+
+	data, err := original.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON returned error: %v", err)
+	}
+	t.Log(string(data))
+
+	restored := new(Graph)
+	if err := restored.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON returned error: %v", err)
+	}
+
+	if restored.Type != original.Type {
+		t.Fatalf("expected Type %v, got %v", original.Type, restored.Type)
+	}
+
+	restoredSrc, ok := restored.Vertex(vertices[0].ID())
+	if !ok {
+		t.Fatalf("expected vertex %s to survive the round-trip", vertices[0].ID())
+	}
+	restoredDest, ok := restored.Vertex(vertices[2].ID())
+	if !ok {
+		t.Fatalf("expected vertex %s to survive the round-trip", vertices[2].ID())
+	}
+
+	path := restored.ShortestPath(restoredSrc, restoredDest)
+	if d := path.Distance(); d != 3 {
+		t.Fatalf("expected distance 3 after round-trip, got %d", d)
+	}
+
+	next := restored.NewVertex()
+	if next.ID() <= vertices[2].ID() {
+		t.Fatalf("expected new vertex ID to continue past restored IDs, got %s", next.ID())
+	}
+}
@@ -0,0 +1,90 @@
+package graph
+
+import (
+	"runtime"
+	"sync"
+)
+
+// ShortestPathsManyToMany returns a len(sources) x len(targets) matrix of
+// shortest paths, where the path at [i][j] runs from sources[i] to
+// targets[j]. Rather than running one independent Dijkstra search per
+// source and filtering the results afterward, each search stops as soon as
+// every requested target has been settled, reusing the early-stop
+// capability of dijkstra's handler. For Bidirectional graphs, searches for
+// independent sources run concurrently across a GOMAXPROCS worker pool,
+// matching the batching pattern used for route-planning workloads where a
+// fleet of origins queries a fleet of destinations.
+//
+// As with ShortestPath, a target equal to its source is reported the same
+// way as an unreachable target: the zero Path, whose Distance is -1. Skip
+// the diagonal when sources and targets overlap if that ambiguity matters
+// to the caller.
+func (g *Graph) ShortestPathsManyToMany(sources, targets []Vertex) [][]Path {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	result := make([][]Path, len(sources))
+	search := func(i int) {
+		result[i] = g.shortestPathsTo(sources[i], targets)
+	}
+
+	if g.Type != Bidirectional {
+		for i := range sources {
+			search(i)
+		}
+		return result
+	}
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(sources) {
+		workers = len(sources)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				search(i)
+			}
+		}()
+	}
+	for i := range sources {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return result
+}
+
+// shortestPathsTo runs a single Dijkstra search from source, stopping early
+// once every vertex in targets has been settled, and returns the shortest
+// path to each target in the same order as targets.
+func (g *Graph) shortestPathsTo(source Vertex, targets []Vertex) []Path {
+	remaining := make(map[vertexible]bool, len(targets))
+	for _, target := range targets {
+		if target.vertex != source.vertex {
+			remaining[target.vertex] = true
+		}
+	}
+
+	found := make(map[vertexible]Path, len(targets))
+	g.dijkstra(source.vertex, nil, func(v vertexible, p Path) bool {
+		if remaining[v] {
+			found[v] = p
+			delete(remaining, v)
+		}
+		return len(remaining) > 0
+	})
+
+	paths := make([]Path, len(targets))
+	for i, target := range targets {
+		if target.vertex != source.vertex {
+			paths[i] = found[target.vertex]
+		}
+	}
+	return paths
+}
@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestShortestPathsManyToMany(t *testing.T) {
+	graph := New(Directional)
+	vertices := []Vertex{
+		graph.NewVertex(),
+		graph.NewVertex(),
+		graph.NewVertex(),
+		graph.NewVertex(),
+	}
+	graph.AddEdge(vertices[0], vertices[1], 1)
+	graph.AddEdge(vertices[1], vertices[2], 2)
+	graph.AddEdge(vertices[0], vertices[3], 9)
+	graph.AddEdge(vertices[2], vertices[3], 1)
+
+	sources := []Vertex{vertices[0], vertices[1]}
+	targets := []Vertex{vertices[2], vertices[3]}
+
+	matrix := graph.ShortestPathsManyToMany(sources, targets)
+	if len(matrix) != len(sources) {
+		t.Fatalf("expected %d rows, got %d", len(sources), len(matrix))
+	}
+	if d := matrix[0][0].Distance(); d != 3 {
+		t.Fatalf("expected distance 3 from vertex 0 to 2, got %d", d)
+	}
+	if d := matrix[0][1].Distance(); d != 4 {
+		t.Fatalf("expected distance 4 from vertex 0 to 3, got %d", d)
+	}
+	if d := matrix[1][0].Distance(); d != 2 {
+		t.Fatalf("expected distance 2 from vertex 1 to 2, got %d", d)
+	}
+}
+
+func TestShortestPathsManyToManyBidirectional(t *testing.T) {
+	graph := New(Bidirectional)
+	vertices := []Vertex{
+		graph.NewVertex(),
+		graph.NewVertex(),
+		graph.NewVertex(),
+	}
+	graph.AddEdge(vertices[0], vertices[1], 1)
+	graph.AddEdge(vertices[1], vertices[2], 2)
+
+	sources := []Vertex{vertices[0], vertices[2]}
+	targets := []Vertex{vertices[1]}
+
+	matrix := graph.ShortestPathsManyToMany(sources, targets)
+	if d := matrix[0][0].Distance(); d != 1 {
+		t.Fatalf("expected distance 1 from vertex 0 to 1, got %d", d)
+	}
+	if d := matrix[1][0].Distance(); d != 2 {
+		t.Fatalf("expected distance 2 from vertex 2 to 1, got %d", d)
+	}
+}
+
+// TestShortestPathsManyToManyMatchesBellmanFord guards against the
+// reconstructed Path slices aliasing each other's backing array when a
+// vertex relaxes several successors off the same prefix, by cross-checking
+// against the independently implemented BellmanFordFrom on a graph with wide
+// fan-out.
+func TestShortestPathsManyToManyMatchesBellmanFord(t *testing.T) {
+	graph := New(Directional)
+	rng := rand.New(rand.NewSource(1))
+	vertices := make([]Vertex, 200)
+	for i := range vertices {
+		vertices[i] = graph.NewVertex()
+	}
+	for i, v := range vertices {
+		for e := 0; e < 5; e++ {
+			to := rng.Intn(len(vertices))
+			if to == i {
+				continue
+			}
+			graph.AddEdge(v, vertices[to], rng.Intn(20)+1)
+		}
+	}
+
+	matrix := graph.ShortestPathsManyToMany(vertices[:1], vertices)
+	reference, ok := graph.BellmanFordFrom(vertices[0])
+	if !ok {
+		t.Fatal("expected no negative cycle")
+	}
+
+	for j, to := range vertices {
+		got := matrix[0][j].Distance()
+		want := -1
+		if p, ok := reference[to]; ok {
+			want = p.Distance()
+		}
+		if got != want {
+			t.Fatalf("distance to vertex %d: got %d, want %d", j, got, want)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package graph
+
+import "math"
+
+// BellmanFordFrom returns shortest paths from src to every vertex reachable
+// from it, tolerating negative edge weights, unlike ShortestPath and
+// ShortestPaths which rely on Dijkstra. ok is false if a negative cycle
+// reachable from src makes shortest paths undefined.
+func (g *Graph) BellmanFordFrom(src Vertex) (paths map[Vertex]Path, ok bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.bellmanFordFrom(src.vertex)
+}
+
+// bellmanFordFrom is the lock-free core of BellmanFordFrom, reused by
+// HasNegativeCycle which already holds g.mu for its whole operation.
+func (g *Graph) bellmanFordFrom(src vertexible) (paths map[Vertex]Path, ok bool) {
+	const inf = math.MaxInt64 / 2
+
+	dist := make(map[vertexible]int, len(g.vertices))
+	pending := make(map[vertexible]Path, len(g.vertices))
+	for _, v := range g.vertices {
+		dist[v] = inf
+	}
+	dist[src] = 0
+
+	for i := 0; i < len(g.vertices)-1; i++ {
+		relaxed := false
+		for _, v := range g.vertices {
+			if dist[v] >= inf {
+				continue
+			}
+			for _, e := range v.edges() {
+				if newDist := dist[v] + e.weight; newDist < dist[e.to] {
+					dist[e.to] = newDist
+					fixedPath := Path{edges: append([]edge(nil), pending[v].edges...)}
+					fixedPath.addEdgeSigned(e)
+					pending[e.to] = fixedPath
+					relaxed = true
+				}
+			}
+		}
+		if !relaxed {
+			break
+		}
+	}
+
+	for _, v := range g.vertices {
+		if dist[v] >= inf {
+			continue
+		}
+		for _, e := range v.edges() {
+			if dist[v]+e.weight < dist[e.to] {
+				return nil, false
+			}
+		}
+	}
+
+	paths = make(map[Vertex]Path, len(pending))
+	for v, p := range pending {
+		paths[v.accessor()] = p
+	}
+	return paths, true
+}
+
+// HasNegativeCycle reports whether g contains a negative-weight cycle
+// reachable from any of its vertices.
+func (g *Graph) HasNegativeCycle() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for _, v := range g.vertices {
+		if _, ok := g.bellmanFordFrom(v); !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// AllShortestPaths returns shortest paths between every pair of vertices in
+// g, computed with the Floyd-Warshall algorithm so that negative edge
+// weights are handled correctly. The outer map is keyed by source vertex,
+// the inner map by destination vertex; a destination is absent from the
+// inner map if it is unreachable from the source.
+func (g *Graph) AllShortestPaths() map[Vertex]map[Vertex]Path {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	const inf = math.MaxInt64 / 2
+
+	ids := make([]vertexible, 0, len(g.vertices))
+	index := make(map[vertexible]int, len(g.vertices))
+	for _, v := range g.vertices {
+		index[v] = len(ids)
+		ids = append(ids, v)
+	}
+	n := len(ids)
+
+	dist := make([][]int, n)
+	nextHop := make([][]int, n)
+	for i := range dist {
+		dist[i] = make([]int, n)
+		nextHop[i] = make([]int, n)
+		for j := range dist[i] {
+			nextHop[i][j] = -1
+			if i == j {
+				dist[i][j] = 0
+			} else {
+				dist[i][j] = inf
+			}
+		}
+	}
+	for i, v := range ids {
+		for _, e := range v.edges() {
+			j := index[e.to]
+			if e.weight < dist[i][j] {
+				dist[i][j] = e.weight
+				nextHop[i][j] = j
+			}
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			if dist[i][k] >= inf {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if dist[k][j] >= inf {
+					continue
+				}
+				if newDist := dist[i][k] + dist[k][j]; newDist < dist[i][j] {
+					dist[i][j] = newDist
+					nextHop[i][j] = nextHop[i][k]
+				}
+			}
+		}
+	}
+
+	// A negative-weight cycle through k makes dist[k][k] negative. Any pair
+	// routed through such a k has an undefined shortest path (it can be made
+	// arbitrarily short by looping), so exclude those pairs from the result
+	// instead of reconstructing a path for them.
+	undefined := make([][]bool, n)
+	for i := range undefined {
+		undefined[i] = make([]bool, n)
+	}
+	for k := 0; k < n; k++ {
+		if dist[k][k] >= 0 {
+			continue
+		}
+		for i := 0; i < n; i++ {
+			if dist[i][k] >= inf {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if dist[k][j] < inf {
+					undefined[i][j] = true
+				}
+			}
+		}
+	}
+
+	result := make(map[Vertex]map[Vertex]Path, n)
+	for i, from := range ids {
+		paths := make(map[Vertex]Path, n)
+		for j, to := range ids {
+			if i == j || dist[i][j] >= inf || undefined[i][j] {
+				continue
+			}
+			paths[to.accessor()] = buildFloydPath(ids, nextHop, i, j)
+		}
+		result[from.accessor()] = paths
+	}
+	return result
+}
+
+// buildFloydPath walks the nextHop chain from i to j and reconstructs the
+// Path by looking up the direct edge weight between each consecutive pair.
+// The walk is capped at len(ids) hops, since a correct chain between two
+// distinct vertices never revisits one; AllShortestPaths excludes pairs
+// whose path would pass through a negative cycle before this is reached, but
+// the cap guards against looping forever all the same.
+func buildFloydPath(ids []vertexible, nextHop [][]int, i, j int) Path {
+	var p Path
+	cur := i
+	for hop := 0; cur != j; hop++ {
+		if hop >= len(ids) {
+			return Path{}
+		}
+		next := nextHop[cur][j]
+		if next < 0 {
+			return Path{}
+		}
+		p.addEdgeSigned(edge{to: ids[next], weight: directEdgeWeight(ids[cur], ids[next])})
+		cur = next
+	}
+	return p
+}
+
+// directEdgeWeight returns the smallest weight among the direct edges from
+// from to to.
+func directEdgeWeight(from, to vertexible) int {
+	best := math.MaxInt64
+	for _, e := range from.edges() {
+		if e.to == to && e.weight < best {
+			best = e.weight
+		}
+	}
+	return best
+}
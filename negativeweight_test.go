@@ -0,0 +1,116 @@
+package graph
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBellmanFordFrom(t *testing.T) {
+	graph := New(Directional)
+	vertices := []Vertex{
+		graph.NewVertex(),
+		graph.NewVertex(),
+		graph.NewVertex(),
+		graph.NewVertex(),
+	}
+	graph.AddEdge(vertices[0], vertices[1], 4)
+	graph.AddEdge(vertices[0], vertices[2], 5)
+	graph.AddEdge(vertices[1], vertices[2], -3)
+	graph.AddEdge(vertices[2], vertices[3], 2)
+
+	paths, ok := graph.BellmanFordFrom(vertices[0])
+	if !ok {
+		t.Fatal("expected no negative cycle")
+	}
+	if d := paths[vertices[2]].Distance(); d != 1 {
+		t.Fatalf("expected distance 1 to vertex 2, got %d", d)
+	}
+	if d := paths[vertices[3]].Distance(); d != 3 {
+		t.Fatalf("expected distance 3 to vertex 3, got %d", d)
+	}
+}
+
+func TestBellmanFordFromDetectsNegativeCycle(t *testing.T) {
+	graph := New(Directional)
+	vertices := []Vertex{
+		graph.NewVertex(),
+		graph.NewVertex(),
+		graph.NewVertex(),
+	}
+	graph.AddEdge(vertices[0], vertices[1], 1)
+	graph.AddEdge(vertices[1], vertices[2], -3)
+	graph.AddEdge(vertices[2], vertices[1], 1)
+
+	if _, ok := graph.BellmanFordFrom(vertices[0]); ok {
+		t.Fatal("expected a negative cycle to be detected")
+	}
+	if !graph.HasNegativeCycle() {
+		t.Fatal("expected HasNegativeCycle to report true")
+	}
+}
+
+func TestBellmanFordFromDoesNotAliasPaths(t *testing.T) {
+	graph := New(Directional)
+	vertices := []Vertex{
+		graph.NewVertex(),
+		graph.NewVertex(),
+		graph.NewVertex(),
+	}
+	graph.AddEdge(vertices[0], vertices[1], 1)
+	graph.AddEdge(vertices[0], vertices[2], 1)
+
+	paths, ok := graph.BellmanFordFrom(vertices[0])
+	if !ok {
+		t.Fatal("expected no negative cycle")
+	}
+	if d := paths[vertices[1]].Distance(); d != 1 {
+		t.Fatalf("expected distance 1 to vertex 1, got %d", d)
+	}
+	if d := paths[vertices[2]].Distance(); d != 1 {
+		t.Fatalf("expected distance 1 to vertex 2, got %d", d)
+	}
+}
+
+func TestAllShortestPaths(t *testing.T) {
+	graph := New(Directional)
+	vertices := []Vertex{
+		graph.NewVertex(),
+		graph.NewVertex(),
+		graph.NewVertex(),
+	}
+	graph.AddEdge(vertices[0], vertices[1], 1)
+	graph.AddEdge(vertices[1], vertices[2], 2)
+	graph.AddEdge(vertices[0], vertices[2], 10)
+
+	all := graph.AllShortestPaths()
+	if d := all[vertices[0]][vertices[2]].Distance(); d != 3 {
+		t.Fatalf("expected distance 3 from vertex 0 to 2, got %d", d)
+	}
+	if _, ok := all[vertices[2]][vertices[0]]; ok {
+		t.Fatal("did not expect a path from vertex 2 to 0")
+	}
+}
+
+func TestAllShortestPathsExcludesNegativeCycle(t *testing.T) {
+	graph := New(Directional)
+	vertices := []Vertex{
+		graph.NewVertex(),
+		graph.NewVertex(),
+		graph.NewVertex(),
+	}
+	graph.AddEdge(vertices[0], vertices[1], 1)
+	graph.AddEdge(vertices[1], vertices[2], -3)
+	graph.AddEdge(vertices[2], vertices[1], 1)
+
+	done := make(chan map[Vertex]map[Vertex]Path, 1)
+	go func() { done <- graph.AllShortestPaths() }()
+
+	select {
+	case all := <-done:
+		if _, ok := all[vertices[0]][vertices[1]]; ok {
+			t.Fatal("did not expect a path through a negative cycle")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("AllShortestPaths did not return for a graph with a negative cycle")
+	}
+}
@@ -0,0 +1,76 @@
+package graph
+
+import "container/heap"
+
+// distancePQ is the priority-queue abstraction dijkstra relies on to
+// repeatedly extract the closest unsettled vertex and to lower a vertex's
+// tentative distance as shorter paths to it are discovered. indexedHeap and
+// fibonacciHeap are its two implementations.
+type distancePQ interface {
+	Len() int
+	pop() edge
+	decreaseKey(v vertexible, weight int)
+}
+
+// indexedHeap is a distancePQ backed by container/heap, augmented with a
+// map from vertex to heap index. Tracking the index lets decreaseKey call
+// heap.Fix directly in O(log V), instead of the O(V) linear scan the
+// original distanceHeap needed to locate the vertex before fixing it up.
+type indexedHeap struct {
+	items distanceHeap
+	index map[vertexible]int
+}
+
+// newIndexedHeap builds an indexedHeap from items in O(V) and returns it as
+// a distancePQ, ready for pop and decreaseKey.
+func newIndexedHeap(items []edge) distancePQ {
+	h := &indexedHeap{
+		items: distanceHeap(items),
+		index: make(map[vertexible]int, len(items)),
+	}
+	for i, e := range h.items {
+		h.index[e.to] = i
+	}
+	heap.Init(h)
+	return h
+}
+
+func (h *indexedHeap) Len() int { return h.items.Len() }
+
+func (h *indexedHeap) pop() edge {
+	return heap.Pop(h).(edge)
+}
+
+func (h *indexedHeap) decreaseKey(v vertexible, weight int) {
+	i, ok := h.index[v]
+	if !ok {
+		return
+	}
+	h.items[i].weight = weight
+	heap.Fix(h, i)
+}
+
+// Less and Swap satisfy heap.Interface by delegating to items, keeping
+// index in sync with every swap.
+func (h *indexedHeap) Less(i, j int) bool { return h.items.Less(i, j) }
+
+func (h *indexedHeap) Swap(i, j int) {
+	h.items.Swap(i, j)
+	h.index[h.items[i].to] = i
+	h.index[h.items[j].to] = j
+}
+
+func (h *indexedHeap) Push(x interface{}) {
+	e := x.(edge)
+	h.index[e.to] = len(h.items)
+	h.items = append(h.items, e)
+}
+
+func (h *indexedHeap) Pop() interface{} {
+	old := h.items
+	size := len(old)
+	popped := old[size-1]
+	h.items = old[:size-1]
+	delete(h.index, popped.to)
+	return popped
+}
@@ -0,0 +1,37 @@
+package graph
+
+// Snapshot returns an immutable deep copy of g. Long-running algorithms,
+// such as running many searches in parallel, can operate on the snapshot
+// without holding any lock, while the original Graph keeps accepting
+// concurrent mutations. The returned Graph must not be mutated.
+func (g *Graph) Snapshot() *Graph {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var maxID VertexID
+	vertices := make(map[VertexID]vertexible, len(g.vertices))
+	for id, v := range g.vertices {
+		value := new(interface{})
+		if orig := v.accessor().Value; orig != nil {
+			*value = *orig
+		}
+		newVertex := &vertex{VertexID: id}
+		newVertex.container = Vertex{vertex: newVertex, Value: value}
+		vertices[id] = newVertex
+		if id > maxID {
+			maxID = id
+		}
+	}
+	for id, v := range g.vertices {
+		from := vertices[id]
+		for _, e := range v.edges() {
+			from.addEdge(vertices[e.to.id()], e.weight)
+		}
+	}
+
+	return &Graph{
+		Type:       g.Type,
+		vertices:   vertices,
+		generateID: newIDGenerator(maxID),
+	}
+}
@@ -0,0 +1,49 @@
+package graph
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestConcurrentAddEdge(t *testing.T) {
+	graph := New(Directional)
+	vertices := make([]Vertex, 50)
+	for i := range vertices {
+		vertices[i] = graph.NewVertex()
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < len(vertices)-1; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			graph.AddEdge(vertices[i], vertices[i+1], 1)
+		}(i)
+	}
+	wg.Wait()
+
+	path := graph.ShortestPath(vertices[0], vertices[len(vertices)-1])
+	if d := path.Distance(); d != len(vertices)-1 {
+		t.Fatalf("expected distance %d, got %d", len(vertices)-1, d)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	graph := New(Directional)
+	vertices := []Vertex{graph.NewVertex(), graph.NewVertex(), graph.NewVertex()}
+	graph.AddEdge(vertices[0], vertices[1], 1)
+	graph.AddEdge(vertices[1], vertices[2], 2)
+
+	snap := graph.Snapshot()
+
+	graph.AddEdge(vertices[0], vertices[2], 1)
+
+	snapSrc, _ := snap.Vertex(vertices[0].ID())
+	snapDest, _ := snap.Vertex(vertices[2].ID())
+	if d := snap.ShortestPath(snapSrc, snapDest).Distance(); d != 3 {
+		t.Fatalf("expected snapshot distance 3 (unaffected by later mutation), got %d", d)
+	}
+	if d := graph.ShortestPath(vertices[0], vertices[2]).Distance(); d != 1 {
+		t.Fatalf("expected live graph distance 1, got %d", d)
+	}
+}
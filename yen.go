@@ -0,0 +1,141 @@
+package graph
+
+import (
+	"container/heap"
+	"strings"
+)
+
+// yenCandidate is a candidate path awaiting consideration in KShortestPaths,
+// ordered by total distance.
+type yenCandidate struct {
+	path Path
+}
+
+type yenHeap []yenCandidate
+
+func (h yenHeap) Len() int            { return len(h) }
+func (h yenHeap) Less(i, j int) bool  { return h[i].path.Distance() < h[j].path.Distance() }
+func (h yenHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *yenHeap) Push(x interface{}) { *h = append(*h, x.(yenCandidate)) }
+func (h *yenHeap) Pop() interface{} {
+	old := *h
+	size := len(old)
+	popped := old[size-1]
+	*h = old[:size-1]
+	return popped
+}
+
+// KShortestPaths returns up to k loopless shortest paths from src to dest,
+// in increasing order of distance, using Yen's algorithm on top of the
+// existing Dijkstra. If fewer than k paths exist, KShortestPaths returns as
+// many as it found.
+func (g *Graph) KShortestPaths(src, dest Vertex, k int) []Path {
+	if k <= 0 {
+		return nil
+	}
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	first := g.shortestPath(src.vertex, dest.vertex)
+	if _, ok := first.Destination(); !ok {
+		return []Path{}
+	}
+
+	result := []Path{first}
+	seen := map[string]bool{pathKey(first): true}
+	candidates := &yenHeap{}
+	heap.Init(candidates)
+
+	for len(result) < k {
+		prev := result[len(result)-1]
+
+		for j := 0; j < len(prev.edges); j++ {
+			spurNode := src.vertex
+			if j > 0 {
+				spurNode = prev.edges[j-1].to
+			}
+			rootEdges := prev.edges[:j]
+
+			excl := &dijkstraExclusions{
+				vertices: make(map[VertexID]bool),
+				edges:    make(map[vertexPair]bool),
+			}
+			for _, p := range result {
+				if sharesRoot(p.edges, rootEdges) {
+					excl.edges[vertexPair{from: spurNode.id(), to: p.edges[j].to.id()}] = true
+				}
+			}
+			if spurNode != src.vertex {
+				excl.vertices[src.vertex.id()] = true
+			}
+			for _, e := range rootEdges {
+				if e.to.id() != spurNode.id() {
+					excl.vertices[e.to.id()] = true
+				}
+			}
+
+			var spurPath Path
+			found := false
+			if spurNode == dest.vertex {
+				found = true
+			} else {
+				g.dijkstra(spurNode, excl, func(v vertexible, p Path) bool {
+					if v == dest.vertex {
+						spurPath = p
+						found = true
+						return false
+					}
+					return true
+				})
+			}
+			if !found {
+				continue
+			}
+
+			candidateEdges := make([]edge, 0, len(rootEdges)+len(spurPath.edges))
+			candidateEdges = append(candidateEdges, rootEdges...)
+			candidateEdges = append(candidateEdges, spurPath.edges...)
+			candidate := Path{edges: candidateEdges}
+
+			key := pathKey(candidate)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			heap.Push(candidates, yenCandidate{path: candidate})
+		}
+
+		if candidates.Len() == 0 {
+			break
+		}
+		result = append(result, heap.Pop(candidates).(yenCandidate).path)
+	}
+
+	return result
+}
+
+// sharesRoot reports whether edges is longer than root and begins with the
+// same sequence of vertices and weights as root.
+func sharesRoot(edges, root []edge) bool {
+	if len(edges) <= len(root) {
+		return false
+	}
+	for i, e := range root {
+		if edges[i].to != e.to || edges[i].weight != e.weight {
+			return false
+		}
+	}
+	return true
+}
+
+// pathKey returns a string uniquely identifying the sequence of vertices
+// visited by p, for use as a seen-path marker.
+func pathKey(p Path) string {
+	var b strings.Builder
+	for _, e := range p.edges {
+		b.WriteString(e.to.id().String())
+		b.WriteByte('>')
+	}
+	return b.String()
+}
@@ -0,0 +1,83 @@
+package graph
+
+import "testing"
+
+func TestKShortestPaths(t *testing.T) {
+	graph := New(Directional)
+	vertices := []Vertex{
+		graph.NewVertex(),
+		graph.NewVertex(),
+		graph.NewVertex(),
+		graph.NewVertex(),
+		graph.NewVertex(),
+		graph.NewVertex(),
+	}
+	graph.AddEdge(vertices[0], vertices[1], 3)
+	graph.AddEdge(vertices[0], vertices[2], 2)
+	graph.AddEdge(vertices[1], vertices[3], 4)
+	graph.AddEdge(vertices[2], vertices[1], 1)
+	graph.AddEdge(vertices[2], vertices[3], 5)
+	graph.AddEdge(vertices[3], vertices[4], 1)
+	graph.AddEdge(vertices[1], vertices[4], 2)
+
+	paths := graph.KShortestPaths(vertices[0], vertices[4], 3)
+	if len(paths) != 3 {
+		t.Fatalf("expected 3 paths, got %d", len(paths))
+	}
+	for i := 1; i < len(paths); i++ {
+		if paths[i-1].Distance() > paths[i].Distance() {
+			t.Fatalf("expected non-decreasing distances, got %d before %d",
+				paths[i-1].Distance(), paths[i].Distance())
+		}
+	}
+	for _, p := range paths {
+		dest, ok := p.Destination()
+		if !ok || dest.ID() != vertices[4].ID() {
+			t.Fatalf("expected destination %s, got %v (ok=%v)", vertices[4].ID(), dest, ok)
+		}
+	}
+}
+
+// TestKShortestPathsAreLoopless guards against a spur search routing back
+// through src: with A->B->C as the shortest path, B->A as a cheap detour,
+// and A->C as a direct but expensive edge, the spur search from B used to
+// revisit A unless A itself was excluded from the root prefix.
+func TestKShortestPathsAreLoopless(t *testing.T) {
+	graph := New(Directional)
+	vertices := []Vertex{
+		graph.NewVertex(),
+		graph.NewVertex(),
+		graph.NewVertex(),
+	}
+	a, b, c := vertices[0], vertices[1], vertices[2]
+	graph.AddEdge(a, b, 1)
+	graph.AddEdge(b, c, 1)
+	graph.AddEdge(b, a, 1)
+	graph.AddEdge(a, c, 10)
+
+	paths := graph.KShortestPaths(a, c, 3)
+	for _, p := range paths {
+		seen := map[VertexID]bool{a.ID(): true}
+		p.IterateEdge(func(to Vertex, weight int) bool {
+			if seen[to.ID()] {
+				t.Fatalf("path %v revisits vertex %s", p, to.ID())
+			}
+			seen[to.ID()] = true
+			return true
+		})
+	}
+}
+
+func TestKShortestPathsFewerThanK(t *testing.T) {
+	graph := New(Directional)
+	vertices := []Vertex{
+		graph.NewVertex(),
+		graph.NewVertex(),
+	}
+	graph.AddEdge(vertices[0], vertices[1], 1)
+
+	paths := graph.KShortestPaths(vertices[0], vertices[1], 5)
+	if len(paths) != 1 {
+		t.Fatalf("expected 1 path, got %d", len(paths))
+	}
+}